@@ -0,0 +1,40 @@
+// Command permit-openapi-dump prints the (method, path template) -> resource:action mapping that
+// enforcement/openapi would derive from an OpenAPI 3 document, so it can be reviewed in a PR
+// alongside the spec change that produced it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/permitio/permit-golang/pkg/enforcement/openapi"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s <openapi-spec-path>\n", os.Args[0])
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	idx, err := openapi.NewURLPolicyIndexFromFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "permit-openapi-dump: %s\n", err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tRESOURCE\tACTION")
+	for _, route := range idx.Routes() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", route.Method, route.PathTemplate, route.Permission.Resource, route.Permission.Action)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "permit-openapi-dump: %s\n", err)
+		os.Exit(1)
+	}
+}
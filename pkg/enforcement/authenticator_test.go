@@ -0,0 +1,117 @@
+package enforcement
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBearerAuthSetsAuthorizationHeaderFromTokenFunc(t *testing.T) {
+	calls := 0
+	auth := BearerAuth{Token: func() string {
+		calls++
+		return "tok-" + string(rune('0'+calls))
+	}}
+
+	req1 := httptest.NewRequest(http.MethodGet, "/x", nil)
+	if err := auth.Apply(req1); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got := req1.Header.Get("Authorization"); got != "Bearer tok-1" {
+		t.Errorf("first request: got %q, want %q", got, "Bearer tok-1")
+	}
+
+	req2 := httptest.NewRequest(http.MethodGet, "/x", nil)
+	if err := auth.Apply(req2); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got := req2.Header.Get("Authorization"); got != "Bearer tok-2" {
+		t.Errorf("second request should re-call Token: got %q, want %q", got, "Bearer tok-2")
+	}
+}
+
+func TestBasicAuthSetsCredentials(t *testing.T) {
+	auth := BasicAuth{Username: "alice", Password: "hunter2"}
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	if err := auth.Apply(req); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	user, pass, ok := req.BasicAuth()
+	if !ok {
+		t.Fatal("expected basic auth credentials to be set")
+	}
+	if user != "alice" || pass != "hunter2" {
+		t.Errorf("got user=%q pass=%q, want user=%q pass=%q", user, pass, "alice", "hunter2")
+	}
+}
+
+func TestHeaderAuthSetsNamedHeaderFromValueFunc(t *testing.T) {
+	auth := HeaderAuth{Name: "X-Api-Key", Value: func() string { return "secret" }}
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	if err := auth.Apply(req); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got := req.Header.Get("X-Api-Key"); got != "secret" {
+		t.Errorf("got %q, want %q", got, "secret")
+	}
+}
+
+func TestMTLSAuthApplyIsNoOp(t *testing.T) {
+	auth := MTLSAuth{TLSConfig: &tls.Config{}}
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	before := req.Clone(req.Context())
+	if err := auth.Apply(req); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if req.Header.Get("Authorization") != before.Header.Get("Authorization") {
+		t.Error("MTLSAuth.Apply should not touch the request at all")
+	}
+}
+
+func TestMTLSAuthConfigureClientClonesDefaultTransportWhenNoneSet(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "pdp.internal"}
+	auth := MTLSAuth{TLSConfig: tlsConfig}
+	client := &http.Client{}
+
+	auth.ConfigureClient(client)
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected client.Transport to be *http.Transport, got %T", client.Transport)
+	}
+	if transport.TLSClientConfig != tlsConfig {
+		t.Error("TLSClientConfig was not set to the provided config")
+	}
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	if transport.Proxy == nil {
+		t.Error("expected Proxy to be carried over from http.DefaultTransport, got nil")
+	}
+	if transport.MaxIdleConns != defaultTransport.MaxIdleConns {
+		t.Errorf("expected MaxIdleConns to match http.DefaultTransport (%d), got %d",
+			defaultTransport.MaxIdleConns, transport.MaxIdleConns)
+	}
+}
+
+func TestMTLSAuthConfigureClientClonesExistingTransport(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "pdp.internal"}
+	auth := MTLSAuth{TLSConfig: tlsConfig}
+	existing := &http.Transport{MaxIdleConns: 7}
+	client := &http.Client{Transport: existing}
+
+	auth.ConfigureClient(client)
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected client.Transport to be *http.Transport, got %T", client.Transport)
+	}
+	if transport == existing {
+		t.Error("ConfigureClient should clone the existing transport, not mutate it in place")
+	}
+	if transport.MaxIdleConns != 7 {
+		t.Errorf("expected cloned transport to keep MaxIdleConns=7, got %d", transport.MaxIdleConns)
+	}
+	if transport.TLSClientConfig != tlsConfig {
+		t.Error("TLSClientConfig was not set to the provided config")
+	}
+}
@@ -0,0 +1,38 @@
+package enforcement
+
+import "sync"
+
+// runBulkFallback runs do(i) for every i in [0, n), with at most concurrency calls in flight at
+// once, and assembles the results in index order regardless of which goroutine finishes first.
+// If any call returns an error, runBulkFallback returns the first one (by index) once all calls
+// have completed. Split out of checkUrlBulkFallback so the ordering/concurrency behavior can be
+// unit tested without a PermitEnforcer or any network I/O.
+func runBulkFallback(n, concurrency int, do func(i int) (CheckUrlResponse, error)) ([]CheckUrlResponse, error) {
+	results := make([]CheckUrlResponse, n)
+	errs := make([]error, n)
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result, err := do(i)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,80 @@
+package enforcement
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBulkFallbackPreservesOrderRegardlessOfCompletionOrder(t *testing.T) {
+	const n = 8
+	results, err := runBulkFallback(n, 3, func(i int) (CheckUrlResponse, error) {
+		// Completion order is the reverse of index order, so a correct implementation must be
+		// assembling by index, not by whichever goroutine finishes first.
+		time.Sleep(time.Duration(n-i) * time.Millisecond)
+		return CheckUrlResponse{Allow: i%2 == 0, Result: i%2 == 0}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != n {
+		t.Fatalf("expected %d results, got %d", n, len(results))
+	}
+	for i, r := range results {
+		want := i%2 == 0
+		if r.Allow != want {
+			t.Errorf("result[%d].Allow = %v, want %v", i, r.Allow, want)
+		}
+	}
+}
+
+func TestRunBulkFallbackBoundsConcurrency(t *testing.T) {
+	const n = 20
+	const concurrency = 4
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+
+	_, err := runBulkFallback(n, concurrency, func(i int) (CheckUrlResponse, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return CheckUrlResponse{}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxInFlight > concurrency {
+		t.Errorf("observed %d calls in flight at once, want at most %d", maxInFlight, concurrency)
+	}
+}
+
+func TestRunBulkFallbackReturnsErrorAfterAllComplete(t *testing.T) {
+	wantErr := errors.New("boom")
+	var completed int32
+
+	_, err := runBulkFallback(5, 2, func(i int) (CheckUrlResponse, error) {
+		defer atomic.AddInt32(&completed, 1)
+		if i == 2 {
+			return CheckUrlResponse{}, wantErr
+		}
+		return CheckUrlResponse{}, nil
+	})
+	if err != wantErr {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+	if got := atomic.LoadInt32(&completed); got != 5 {
+		t.Fatalf("expected all 5 calls to complete before returning, got %d", got)
+	}
+}
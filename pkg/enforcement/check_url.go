@@ -2,10 +2,13 @@ package enforcement
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
+	"github.com/permitio/permit-golang/pkg/enforcement/localpolicy"
 	"github.com/permitio/permit-golang/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -100,8 +103,51 @@ func (e *PermitEnforcer) parseCheckUrlResponse(res *http.Response) (*CheckUrlRes
 	return &result, nil
 }
 
+// CheckUrl checks whether the user is allowed to perform the given method on the given URL, under
+// the given tenant. It blocks for up to the enforcer's configured timeout (DefaultTimeout if
+// unset); use CheckUrlWithContext to bound the call with the caller's own deadline or to cancel it
+// early.
 func (e *PermitEnforcer) CheckUrl(user User, url URL, method Method, tenant Tenant, additionalContext ...map[string]string) (bool, error) {
-	reqAuthValue := "Bearer " + e.config.GetToken()
+	return e.CheckUrlWithContext(context.Background(), user, url, method, tenant, additionalContext...)
+}
+
+// CheckUrlWithContext is the context-aware equivalent of CheckUrl. The local-policy pre-filter
+// (see SetLocalPolicyEngine) is consulted first and can resolve the request with zero network I/O
+// even if ctx is already canceled or expired - that's the whole point of an offline pre-filter,
+// and it's what lets checkUrlBulkFallback keep making progress on the remaining items once a
+// shared deadline elapses mid-batch. Only once the local engine returns localpolicy.Unknown (or
+// isn't configured) does ctx.Err() get checked: if ctx is already done, CheckUrlWithContext
+// returns without making a network round-trip to the PDP. Otherwise it derives a child context
+// bounded by the enforcer's configured timeout (falling back to DefaultTimeout) and propagates it
+// to the underlying http.Request, so a slow or unreachable PDP can't block the caller
+// indefinitely. If the deadline is reached before a decision comes back, the returned error wraps
+// an errors.PermitDeadlineExceeded so callers can tell "PDP denied" apart from "PDP too
+// slow/unreachable" and fail open or closed accordingly. That holds under FailClosed; under
+// FailOpen, and under FailLocal when the local engine has a decision, a failed request to the PDP
+// (deadline included) is resolved by the FailureMode and returns a nil error.
+func (e *PermitEnforcer) CheckUrlWithContext(ctx context.Context, user User, url URL, method Method, tenant Tenant, additionalContext ...map[string]string) (bool, error) {
+	if e.localPolicyEngine != nil {
+		switch e.localPolicyEngine.Evaluate(localpolicy.Request{Method: string(method), Path: string(url), Tenant: string(tenant)}) {
+		case localpolicy.Allow:
+			return true, nil
+		case localpolicy.Deny:
+			return false, nil
+		}
+		// localpolicy.Unknown: no local rule matched, fall through to ctx.Err()/the PDP below.
+	}
+
+	if err := ctx.Err(); err != nil {
+		permitError := errors.NewPermitDeadlineExceeded(err)
+		e.logger.Error("Permit.AllowedUrl() context already done, not calling PDP", zap.Error(permitError))
+		return false, permitError
+	}
+
+	timeout := e.config.GetTimeout()
+	if timeout <= 0 {
+		timeout = DefaultTimeout * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	if additionalContext == nil {
 		additionalContext = make([]map[string]string, 0)
@@ -114,16 +160,39 @@ func (e *PermitEnforcer) CheckUrl(user User, url URL, method Method, tenant Tena
 		return false, permitError
 	}
 	reqBody := bytes.NewBuffer(jsonAllowedUrlReq)
-	httpRequest, err := http.NewRequest(reqMethod, e.getAllowedUrlEndpoint(), reqBody)
+	httpRequest, err := http.NewRequestWithContext(ctx, reqMethod, e.getAllowedUrlEndpoint(), reqBody)
 	if err != nil {
 		permitError := errors.NewPermitUnexpectedError(err, nil)
 		e.logger.Error("error creating Permit.AllowedUrl() request", zap.Error(permitError))
 		return false, permitError
 	}
 	httpRequest.Header.Set(reqContentTypeKey, reqContentTypeValue)
-	httpRequest.Header.Set(reqAuthKey, reqAuthValue)
+	if err := e.applyAuth(httpRequest); err != nil {
+		permitError := errors.NewPermitUnexpectedError(err, nil)
+		e.logger.Error("error authenticating Permit.AllowedUrl() request", zap.Error(permitError))
+		return false, permitError
+	}
 	res, err := e.client.Do(httpRequest)
 	if err != nil {
+		// FailLocal is consulted before treating err as a hard failure, regardless of whether
+		// the PDP refused the connection outright or the context deadline elapsed mid-flight -
+		// both are "PDP unreachable" from the caller's point of view, and checking ctx.Err()
+		// first would make the local fallback unreachable for the (most common) timeout case.
+		if e.config.GetFailureMode() == FailLocal && e.localPolicyEngine != nil {
+			if decision := e.localPolicyEngine.Evaluate(localpolicy.Request{Method: string(method), Path: string(url), Tenant: string(tenant)}); decision != localpolicy.Unknown {
+				e.logger.Warn("PDP unreachable, falling back to local policy decision", zap.Error(err), zap.Stringer("decision", decision))
+				return decision == localpolicy.Allow, nil
+			}
+		}
+		if e.config.GetFailureMode() == FailOpen {
+			e.logger.Warn("PDP unreachable, failing open", zap.Error(err))
+			return true, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			permitError := errors.NewPermitDeadlineExceeded(ctxErr)
+			e.logger.Error("Permit.AllowedUrl() request to PDP did not complete before deadline", zap.Error(permitError))
+			return false, permitError
+		}
 		permitError := errors.NewPermitUnexpectedError(err, res)
 		e.logger.Error("error sending Permit.AllowedUrl() request to PDP", zap.Error(permitError))
 		return false, permitError
@@ -0,0 +1,169 @@
+package enforcement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/permitio/permit-golang/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// bulkFallbackConcurrency bounds how many individual CheckUrl calls checkUrlBulkFallback fans out
+// at once, so a large batch doesn't open one connection per request against the PDP.
+const bulkFallbackConcurrency = 10
+
+type checkUrlBulkRequestBody struct {
+	Checks []CheckUrlRequest `json:"checks"`
+}
+
+func newJsonCheckUrlBulkRequest(opaUrl string, requests []CheckUrlRequest) ([]byte, error) {
+	body := &checkUrlBulkRequestBody{Checks: requests}
+	var genericBulkReq interface{} = body
+	if opaUrl != "" {
+		genericBulkReq = &struct {
+			Input *checkUrlBulkRequestBody `json:"input"`
+		}{body}
+	}
+	return json.Marshal(genericBulkReq)
+}
+
+func (e *PermitEnforcer) getAllowedUrlBulkEndpoint() string {
+	return e.getEndpointByPolicyPackage(bulkPolicyPackage)
+}
+
+func (e *PermitEnforcer) parseCheckUrlBulkResponse(res *http.Response) ([]CheckUrlResponse, error) {
+	var result []CheckUrlResponse
+	err := errors.HttpErrorHandle(nil, res)
+	if err != nil {
+		responseBodyZap := zap.String("response_body", "")
+		if permitErr, ok := err.(errors.PermitError); ok {
+			responseBodyZap = zap.String("response_body", permitErr.ResponseBody)
+		}
+		e.logger.Error("erroneous http response from PDP for Permit.CheckUrlBulk()", zap.Error(err), responseBodyZap)
+		return nil, err
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		permitError := errors.NewPermitUnexpectedError(err, nil)
+		e.logger.Error("error reading Permit.CheckUrlBulk() response from PDP", zap.Error(permitError))
+		return nil, permitError
+	}
+
+	if e.config.GetOpaUrl() != "" {
+		opaStruct := &struct {
+			Result []CheckUrlResponse `json:"result"`
+		}{}
+		if err := json.Unmarshal(bodyBytes, opaStruct); err != nil {
+			permitError := errors.NewPermitUnexpectedError(err, nil)
+			e.logger.Error("error unmarshalling Permit.CheckUrlBulk() response from OPA", zap.Error(permitError))
+			return nil, permitError
+		}
+		result = opaStruct.Result
+	} else {
+		if err := json.Unmarshal(bodyBytes, &result); err != nil {
+			permitError := errors.NewPermitUnexpectedError(err, nil)
+			e.logger.Error("error unmarshalling Permit.CheckUrlBulk() response from PDP", zap.Error(permitError))
+			return nil, permitError
+		}
+	}
+
+	return result, nil
+}
+
+// CheckUrlBulk checks a batch of URL/method/tenant combinations for the same user in a single
+// round-trip to the PDP, via the bulkPolicyPackage (/allowed/bulk) endpoint. It blocks for up to
+// the enforcer's configured timeout (DefaultTimeout if unset); use CheckUrlBulkWithContext to
+// bound the call with the caller's own deadline or to cancel it early.
+func (e *PermitEnforcer) CheckUrlBulk(user User, requests []CheckUrlRequest) ([]CheckUrlResponse, error) {
+	return e.CheckUrlBulkWithContext(context.Background(), user, requests)
+}
+
+// CheckUrlBulkWithContext is the context-aware equivalent of CheckUrlBulk. Like
+// CheckUrlWithContext, it returns early without a network round-trip if ctx is already done,
+// derives a child context bounded by the enforcer's configured timeout (falling back to
+// DefaultTimeout), and wraps a deadline/cancellation failure in errors.PermitDeadlineExceeded.
+// The returned slice preserves the order of requests. If the PDP responds 404 (older PDPs that
+// predate /allowed/bulk), it falls back to issuing one CheckUrlWithContext per request, bounded
+// by bulkFallbackConcurrency and the same context.
+func (e *PermitEnforcer) CheckUrlBulkWithContext(ctx context.Context, user User, requests []CheckUrlRequest) ([]CheckUrlResponse, error) {
+	if err := ctx.Err(); err != nil {
+		permitError := errors.NewPermitDeadlineExceeded(err)
+		e.logger.Error("Permit.CheckUrlBulk() context already done, not calling PDP", zap.Error(permitError))
+		return nil, permitError
+	}
+
+	timeout := e.config.GetTimeout()
+	if timeout <= 0 {
+		timeout = DefaultTimeout * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Copy rather than mutate requests in place: it's the caller's slice, and writing into it via
+	// the shared backing array would be a surprising side effect if they reuse it elsewhere (e.g.
+	// a request template shared across tenants).
+	withUser := make([]CheckUrlRequest, len(requests))
+	copy(withUser, requests)
+	for i := range withUser {
+		withUser[i].User = user
+	}
+	requests = withUser
+
+	jsonCheckUrlBulkReq, err := newJsonCheckUrlBulkRequest(e.config.GetOpaUrl(), requests)
+	if err != nil {
+		permitError := errors.NewPermitUnexpectedError(err, nil)
+		e.logger.Error("error marshalling Permit.CheckUrlBulk() request", zap.Error(permitError))
+		return nil, permitError
+	}
+	reqBody := bytes.NewBuffer(jsonCheckUrlBulkReq)
+	httpRequest, err := http.NewRequestWithContext(ctx, reqMethod, e.getAllowedUrlBulkEndpoint(), reqBody)
+	if err != nil {
+		permitError := errors.NewPermitUnexpectedError(err, nil)
+		e.logger.Error("error creating Permit.CheckUrlBulk() request", zap.Error(permitError))
+		return nil, permitError
+	}
+	httpRequest.Header.Set(reqContentTypeKey, reqContentTypeValue)
+	if err := e.applyAuth(httpRequest); err != nil {
+		permitError := errors.NewPermitUnexpectedError(err, nil)
+		e.logger.Error("error authenticating Permit.CheckUrlBulk() request", zap.Error(permitError))
+		return nil, permitError
+	}
+	res, err := e.client.Do(httpRequest)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			permitError := errors.NewPermitDeadlineExceeded(ctxErr)
+			e.logger.Error("Permit.CheckUrlBulk() request to PDP did not complete before deadline", zap.Error(permitError))
+			return nil, permitError
+		}
+		permitError := errors.NewPermitUnexpectedError(err, res)
+		e.logger.Error("error sending Permit.CheckUrlBulk() request to PDP", zap.Error(permitError))
+		return nil, permitError
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		e.logger.Warn("PDP does not support /allowed/bulk, falling back to individual CheckUrl calls",
+			zap.Int("num_requests", len(requests)))
+		return e.checkUrlBulkFallback(ctx, user, requests)
+	}
+
+	return e.parseCheckUrlBulkResponse(res)
+}
+
+// checkUrlBulkFallback parallelizes individual CheckUrlWithContext calls under ctx, capped at
+// bulkFallbackConcurrency in flight at once, and assembles the results in the same order as
+// requests.
+func (e *PermitEnforcer) checkUrlBulkFallback(ctx context.Context, user User, requests []CheckUrlRequest) ([]CheckUrlResponse, error) {
+	return runBulkFallback(len(requests), bulkFallbackConcurrency, func(i int) (CheckUrlResponse, error) {
+		req := requests[i]
+		allowed, err := e.CheckUrlWithContext(ctx, user, req.URL, req.Method, req.Tenant, req.Context)
+		if err != nil {
+			return CheckUrlResponse{}, err
+		}
+		return CheckUrlResponse{Allow: allowed, Result: allowed}, nil
+	})
+}
@@ -0,0 +1,140 @@
+package enforcement
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	permiterrors "github.com/permitio/permit-golang/pkg/errors"
+
+	"go.uber.org/zap"
+
+	"github.com/permitio/permit-golang/pkg/enforcement/localpolicy"
+)
+
+// fakeConfig is a minimal config for exercising CheckUrlWithContext without a real PDP.
+type fakeConfig struct {
+	timeout     time.Duration
+	failureMode FailureMode
+}
+
+func (c fakeConfig) GetToken() string            { return "test-token" }
+func (c fakeConfig) GetOpaUrl() string           { return "" }
+func (c fakeConfig) GetTimeout() time.Duration   { return c.timeout }
+func (c fakeConfig) GetFailureMode() FailureMode { return c.failureMode }
+
+// errorRoundTripper makes every request fail, standing in for an unreachable PDP without opening
+// a real connection.
+type errorRoundTripper struct{ err error }
+
+func (rt errorRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, rt.err
+}
+
+func newTestEnforcer(engine *localpolicy.URLPolicyEngine, failureMode FailureMode) *PermitEnforcer {
+	return &PermitEnforcer{
+		client:            &http.Client{Transport: errorRoundTripper{err: errors.New("connection refused")}},
+		config:            fakeConfig{timeout: time.Second, failureMode: failureMode},
+		logger:            zap.NewNop(),
+		localPolicyEngine: engine,
+	}
+}
+
+func TestCheckUrlWithContextLocalPolicyResolvesDespiteExpiredContext(t *testing.T) {
+	engine, err := localpolicy.NewURLPolicyEngine([]localpolicy.Rule{
+		{Name: "allow health checks", Decision: localpolicy.Allow, PathGlob: "/healthz*"},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+	e := newTestEnforcer(engine, FailClosed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	allowed, err := e.CheckUrlWithContext(ctx, User{}, "/healthz", "GET", DefaultTenant)
+	if err != nil {
+		t.Fatalf("expected the local engine to resolve this without touching ctx.Err(), got error: %v", err)
+	}
+	if !allowed {
+		t.Errorf("expected allowed = true, got false")
+	}
+}
+
+func TestCheckUrlWithContextReturnsDeadlineExceededWhenContextAlreadyDoneAndLocalPolicyUnknown(t *testing.T) {
+	e := newTestEnforcer(nil, FailClosed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := e.CheckUrlWithContext(ctx, User{}, "/orders", "GET", DefaultTenant)
+	if err == nil {
+		t.Fatal("expected an error for an already-done context, got nil")
+	}
+	var deadlineErr *permiterrors.PermitDeadlineExceeded
+	if !errors.As(err, &deadlineErr) {
+		t.Errorf("expected error to wrap PermitDeadlineExceeded, got %v (%T)", err, err)
+	}
+}
+
+func TestCheckUrlWithContextFailOpenOnPDPUnreachable(t *testing.T) {
+	e := newTestEnforcer(nil, FailOpen)
+
+	allowed, err := e.CheckUrlWithContext(context.Background(), User{}, "/orders", "GET", DefaultTenant)
+	if err != nil {
+		t.Fatalf("FailOpen should swallow the PDP-unreachable error, got: %v", err)
+	}
+	if !allowed {
+		t.Errorf("expected allowed = true under FailOpen, got false")
+	}
+}
+
+func TestCheckUrlWithContextFailClosedOnPDPUnreachable(t *testing.T) {
+	e := newTestEnforcer(nil, FailClosed)
+
+	allowed, err := e.CheckUrlWithContext(context.Background(), User{}, "/orders", "GET", DefaultTenant)
+	if err == nil {
+		t.Fatal("expected an error under FailClosed when the PDP is unreachable, got nil")
+	}
+	if allowed {
+		t.Errorf("expected allowed = false under FailClosed, got true")
+	}
+}
+
+// reloadThenFailRoundTripper reloads engine with rules and then fails the request, so the engine
+// returns Unknown to the pre-filter but has a decision by the time the FailLocal fallback runs.
+type reloadThenFailRoundTripper struct {
+	engine *localpolicy.URLPolicyEngine
+	rules  []localpolicy.Rule
+}
+
+func (rt reloadThenFailRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	if err := rt.engine.Reload(rt.rules); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("connection refused")
+}
+
+func TestCheckUrlWithContextFailLocalFallsBackToEngineOnPDPUnreachable(t *testing.T) {
+	engine, err := localpolicy.NewURLPolicyEngine(nil)
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+	e := newTestEnforcer(engine, FailLocal)
+	e.client = &http.Client{Transport: reloadThenFailRoundTripper{
+		engine: engine,
+		rules: []localpolicy.Rule{
+			{Name: "deny writes", Decision: localpolicy.Deny, Methods: []string{"POST"}, PathGlob: "/orders*"},
+		},
+	}}
+
+	allowed, err := e.CheckUrlWithContext(context.Background(), User{}, "/orders", "POST", DefaultTenant)
+	if err != nil {
+		t.Fatalf("FailLocal should resolve via the engine, got error: %v", err)
+	}
+	if allowed {
+		t.Errorf("expected allowed = false (engine denies), got true")
+	}
+}
@@ -0,0 +1,19 @@
+package enforcement
+
+// FailureMode controls how CheckUrl and CheckUrlWithContext behave when the request to the PDP
+// fails (connection error or timeout), as distinct from the PDP actively returning a deny
+// decision. An error status or malformed body in the PDP's response is returned as an error
+// whatever the FailureMode.
+type FailureMode int
+
+const (
+	// FailClosed denies the request when the PDP is unreachable. This is the default and the
+	// safest choice when availability loss should never translate into an accidental allow.
+	FailClosed FailureMode = iota
+	// FailOpen allows the request through when the PDP is unreachable.
+	FailOpen
+	// FailLocal consults the enforcer's configured localpolicy.URLPolicyEngine for a decision
+	// when the PDP is unreachable, falling back to FailClosed if no engine is configured or it
+	// returns localpolicy.Unknown.
+	FailLocal
+)
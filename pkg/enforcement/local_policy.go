@@ -0,0 +1,12 @@
+package enforcement
+
+import "github.com/permitio/permit-golang/pkg/enforcement/localpolicy"
+
+// SetLocalPolicyEngine configures a localpolicy.URLPolicyEngine as a pre-filter for CheckUrl and
+// CheckUrlWithContext: an Allow or Deny decision from the engine skips the PDP round-trip
+// entirely, while Unknown falls through to the PDP as before. The engine is also consulted as the
+// fallback source of truth when the PDP is unreachable and the enforcer's FailureMode is
+// FailLocal. Pass nil to disable it again.
+func (e *PermitEnforcer) SetLocalPolicyEngine(engine *localpolicy.URLPolicyEngine) {
+	e.localPolicyEngine = engine
+}
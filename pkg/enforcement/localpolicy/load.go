@@ -0,0 +1,41 @@
+package localpolicy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ruleFile struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// LoadRulesFile reads and parses a rule file, choosing YAML or JSON based on its extension
+// (.yaml, .yml, or .json).
+func LoadRulesFile(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("localpolicy: reading %s: %w", path, err)
+	}
+
+	var file ruleFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("localpolicy: parsing %s: %w", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("localpolicy: parsing %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("localpolicy: unrecognized rule file extension %q (expected .yaml, .yml or .json)", ext)
+	}
+
+	return file.Rules, nil
+}
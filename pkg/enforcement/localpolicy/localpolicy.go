@@ -0,0 +1,221 @@
+// Package localpolicy implements a local, in-process allow/deny engine for URL checks, so that
+// obvious decisions (health checks, known-bad paths, an internal CIDR range) can skip the PDP
+// round-trip entirely, and so that enforcement can still make a decision when the PDP itself is
+// unreachable.
+package localpolicy
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"regexp"
+	"sync/atomic"
+)
+
+// Decision is the outcome of evaluating a Request against an engine's rules.
+type Decision int
+
+const (
+	// Unknown means no rule matched; the caller should fall through to the PDP.
+	Unknown Decision = iota
+	// Allow means a matching rule explicitly allows the request.
+	Allow
+	// Deny means a matching rule explicitly denies the request.
+	Deny
+)
+
+func (d Decision) String() string {
+	switch d {
+	case Allow:
+		return "allow"
+	case Deny:
+		return "deny"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalText lets Decision be parsed from the "allow"/"deny" strings used in rule files,
+// supporting both encoding/json (via TextUnmarshaler) and yaml.v3.
+func (d *Decision) UnmarshalText(text []byte) error {
+	parsed, err := parseDecision(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Request is the subset of an incoming check that local rules can match against.
+type Request struct {
+	Method   string
+	Path     string
+	Tenant   string
+	RemoteIP net.IP
+}
+
+// Rule is one local policy rule, as loaded from YAML/JSON. Empty Methods/Tenants/CIDRs mean "any".
+type Rule struct {
+	Name      string   `json:"name" yaml:"name"`
+	Decision  Decision `json:"decision" yaml:"decision"`
+	Methods   []string `json:"methods,omitempty" yaml:"methods,omitempty"`
+	Tenants   []string `json:"tenants,omitempty" yaml:"tenants,omitempty"`
+	PathGlob  string   `json:"path_glob,omitempty" yaml:"path_glob,omitempty"`
+	PathRegex string   `json:"path_regex,omitempty" yaml:"path_regex,omitempty"`
+	CIDRs     []string `json:"cidrs,omitempty" yaml:"cidrs,omitempty"`
+}
+
+type compiledRule struct {
+	name      string
+	decision  Decision
+	methods   map[string]struct{}
+	tenants   map[string]struct{}
+	pathGlob  string
+	pathRegex *regexp.Regexp
+	cidrs     []*net.IPNet
+}
+
+func (r *compiledRule) matches(req Request) bool {
+	if len(r.methods) > 0 {
+		if _, ok := r.methods[req.Method]; !ok {
+			return false
+		}
+	}
+	if len(r.tenants) > 0 {
+		if _, ok := r.tenants[req.Tenant]; !ok {
+			return false
+		}
+	}
+	if r.pathGlob != "" {
+		matched, err := path.Match(r.pathGlob, req.Path)
+		if err != nil || !matched {
+			return false
+		}
+	}
+	if r.pathRegex != nil && !r.pathRegex.MatchString(req.Path) {
+		return false
+	}
+	if len(r.cidrs) > 0 {
+		if req.RemoteIP == nil {
+			return false
+		}
+		inAny := false
+		for _, cidr := range r.cidrs {
+			if cidr.Contains(req.RemoteIP) {
+				inAny = true
+				break
+			}
+		}
+		if !inAny {
+			return false
+		}
+	}
+	return true
+}
+
+func compile(rules []Rule) (*compiledRules, error) {
+	compiled := make([]*compiledRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Decision == Unknown {
+			return nil, fmt.Errorf("localpolicy: rule %d (%s): decision must be allow or deny", i, rule.Name)
+		}
+		if rule.PathGlob != "" && rule.PathRegex != "" {
+			return nil, fmt.Errorf("localpolicy: rule %d (%s): path_glob and path_regex are mutually exclusive", i, rule.Name)
+		}
+
+		cr := &compiledRule{
+			name:     rule.Name,
+			decision: rule.Decision,
+			methods:  toSet(rule.Methods),
+			tenants:  toSet(rule.Tenants),
+			pathGlob: rule.PathGlob,
+		}
+		if rule.PathRegex != "" {
+			re, err := regexp.Compile(rule.PathRegex)
+			if err != nil {
+				return nil, fmt.Errorf("localpolicy: rule %d (%s): %w", i, rule.Name, err)
+			}
+			cr.pathRegex = re
+		}
+		for _, rawCIDR := range rule.CIDRs {
+			_, network, err := net.ParseCIDR(rawCIDR)
+			if err != nil {
+				return nil, fmt.Errorf("localpolicy: rule %d (%s): invalid CIDR %q: %w", i, rule.Name, rawCIDR, err)
+			}
+			cr.cidrs = append(cr.cidrs, network)
+		}
+		compiled = append(compiled, cr)
+	}
+	return &compiledRules{rules: compiled}, nil
+}
+
+func parseDecision(s string) (Decision, error) {
+	switch s {
+	case "allow":
+		return Allow, nil
+	case "deny":
+		return Deny, nil
+	default:
+		return Unknown, fmt.Errorf("unrecognized decision %q (expected \"allow\" or \"deny\")", s)
+	}
+}
+
+func toSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+type compiledRules struct {
+	rules []*compiledRule
+}
+
+// URLPolicyEngine evaluates a Request against a compiled set of rules, first match wins. The
+// compiled ruleset is held behind an atomic.Pointer so Evaluate is safe to call concurrently with
+// Reload swapping in a new ruleset, e.g. from a file watcher.
+type URLPolicyEngine struct {
+	compiled atomic.Pointer[compiledRules]
+}
+
+// NewURLPolicyEngine compiles rules and returns a ready-to-use engine.
+func NewURLPolicyEngine(rules []Rule) (*URLPolicyEngine, error) {
+	compiled, err := compile(rules)
+	if err != nil {
+		return nil, err
+	}
+	engine := &URLPolicyEngine{}
+	engine.compiled.Store(compiled)
+	return engine, nil
+}
+
+// Evaluate returns Allow or Deny if a rule matches req, or Unknown if none do - callers should
+// fall through to the PDP on Unknown.
+func (e *URLPolicyEngine) Evaluate(req Request) Decision {
+	compiled := e.compiled.Load()
+	if compiled == nil {
+		return Unknown
+	}
+	for _, rule := range compiled.rules {
+		if rule.matches(req) {
+			return rule.decision
+		}
+	}
+	return Unknown
+}
+
+// Reload compiles rules and atomically swaps them in. Concurrent Evaluate calls either see the
+// old or the new ruleset in full, never a partially-applied one. On error, the previously loaded
+// ruleset is left untouched.
+func (e *URLPolicyEngine) Reload(rules []Rule) error {
+	compiled, err := compile(rules)
+	if err != nil {
+		return err
+	}
+	e.compiled.Store(compiled)
+	return nil
+}
@@ -0,0 +1,179 @@
+package localpolicy
+
+import (
+	"net"
+	"sync"
+	"testing"
+)
+
+func TestEvaluateGlobPathRule(t *testing.T) {
+	engine, err := NewURLPolicyEngine([]Rule{
+		{Name: "health checks", Decision: Allow, PathGlob: "/healthz*"},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/healthz"}); d != Allow {
+		t.Errorf("Evaluate(/healthz) = %v, want Allow", d)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/healthzzz"}); d != Allow {
+		t.Errorf("Evaluate(/healthzzz) = %v, want Allow", d)
+	}
+	// path.Match's "*" doesn't cross "/" boundaries, matching typical glob semantics.
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/healthz/live"}); d != Unknown {
+		t.Errorf("Evaluate(/healthz/live) = %v, want Unknown (glob * shouldn't cross /)", d)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/orders"}); d != Unknown {
+		t.Errorf("Evaluate(/orders) = %v, want Unknown", d)
+	}
+}
+
+func TestEvaluateRegexMethodAndTenantRule(t *testing.T) {
+	engine, err := NewURLPolicyEngine([]Rule{
+		{
+			Name:      "deny internal admin API for tenant acme",
+			Decision:  Deny,
+			Methods:   []string{"POST", "DELETE"},
+			Tenants:   []string{"acme"},
+			PathRegex: `^/admin/.*$`,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+
+	if d := engine.Evaluate(Request{Method: "POST", Path: "/admin/users", Tenant: "acme"}); d != Deny {
+		t.Errorf("matching request: got %v, want Deny", d)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/admin/users", Tenant: "acme"}); d != Unknown {
+		t.Errorf("wrong method should not match: got %v, want Unknown", d)
+	}
+	if d := engine.Evaluate(Request{Method: "POST", Path: "/admin/users", Tenant: "other"}); d != Unknown {
+		t.Errorf("wrong tenant should not match: got %v, want Unknown", d)
+	}
+}
+
+func TestEvaluateCIDRRule(t *testing.T) {
+	engine, err := NewURLPolicyEngine([]Rule{
+		{Name: "internal network", Decision: Allow, CIDRs: []string{"10.0.0.0/8"}},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/x", RemoteIP: net.ParseIP("10.1.2.3")}); d != Allow {
+		t.Errorf("in-range IP: got %v, want Allow", d)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/x", RemoteIP: net.ParseIP("8.8.8.8")}); d != Unknown {
+		t.Errorf("out-of-range IP: got %v, want Unknown", d)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/x"}); d != Unknown {
+		t.Errorf("no remote IP: got %v, want Unknown", d)
+	}
+}
+
+func TestEvaluateFirstMatchingRuleWins(t *testing.T) {
+	engine, err := NewURLPolicyEngine([]Rule{
+		{Name: "deny all", Decision: Deny, PathGlob: "/orders*"},
+		{Name: "allow reads", Decision: Allow, Methods: []string{"GET"}, PathGlob: "/orders*"},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/orders"}); d != Deny {
+		t.Errorf("got %v, want Deny (first matching rule should win)", d)
+	}
+}
+
+func TestNewURLPolicyEngineRejectsInvalidRules(t *testing.T) {
+	cases := []Rule{
+		{Name: "no decision", PathGlob: "/x"},
+		{Name: "bad cidr", Decision: Allow, CIDRs: []string{"not-a-cidr"}},
+		{Name: "bad regex", Decision: Allow, PathRegex: "("},
+		{Name: "both glob and regex", Decision: Allow, PathGlob: "/x", PathRegex: "/x"},
+	}
+	for _, rule := range cases {
+		if _, err := NewURLPolicyEngine([]Rule{rule}); err == nil {
+			t.Errorf("rule %q: expected an error, got nil", rule.Name)
+		}
+	}
+}
+
+func TestReloadSwapsRuleset(t *testing.T) {
+	engine, err := NewURLPolicyEngine([]Rule{
+		{Name: "allow orders", Decision: Allow, PathGlob: "/orders*"},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/orders"}); d != Allow {
+		t.Fatalf("before reload: got %v, want Allow", d)
+	}
+
+	if err := engine.Reload([]Rule{
+		{Name: "deny orders", Decision: Deny, PathGlob: "/orders*"},
+	}); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/orders"}); d != Deny {
+		t.Errorf("after reload: got %v, want Deny", d)
+	}
+}
+
+// TestReloadKeepsPreviousRulesetOnError ensures a bad rule file can't silently blind the engine:
+// Reload must leave the last-known-good ruleset in effect when the new one fails to compile.
+func TestReloadKeepsPreviousRulesetOnError(t *testing.T) {
+	engine, err := NewURLPolicyEngine([]Rule{
+		{Name: "allow orders", Decision: Allow, PathGlob: "/orders*"},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+
+	if err := engine.Reload([]Rule{{Name: "broken", Decision: Allow, PathRegex: "("}}); err == nil {
+		t.Fatal("expected Reload with an invalid rule to return an error")
+	}
+	if d := engine.Evaluate(Request{Method: "GET", Path: "/orders"}); d != Allow {
+		t.Errorf("ruleset should be unchanged after a failed reload: got %v, want Allow", d)
+	}
+}
+
+// TestConcurrentEvaluateDuringReload exercises the atomic.Pointer swap under the race detector:
+// Evaluate must never observe a partially-applied ruleset while Reload is in flight.
+func TestConcurrentEvaluateDuringReload(t *testing.T) {
+	engine, err := NewURLPolicyEngine([]Rule{
+		{Name: "allow orders", Decision: Allow, PathGlob: "/orders*"},
+	})
+	if err != nil {
+		t.Fatalf("NewURLPolicyEngine: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			decision := Allow
+			if i%2 == 1 {
+				decision = Deny
+			}
+			_ = engine.Reload([]Rule{{Name: "toggle", Decision: decision, PathGlob: "/orders*"}})
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		if d := engine.Evaluate(Request{Method: "GET", Path: "/orders"}); d != Allow && d != Deny {
+			t.Fatalf("Evaluate returned %v mid-reload, want Allow or Deny", d)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
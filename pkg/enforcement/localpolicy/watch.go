@@ -0,0 +1,88 @@
+package localpolicy
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// NewWatchingEngine builds a URLPolicyEngine from the rules in path and keeps it up to date:
+// whenever path is written, the rules are re-read and recompiled, and - only if that succeeds -
+// atomically swapped in via Reload. A bad edit (invalid YAML/JSON, a rule that fails to compile)
+// is logged and otherwise ignored, leaving the last-known-good ruleset in effect.
+//
+// The watcher is set up on path's parent directory rather than path itself: editors and
+// deployment tools (vim, a ConfigMap volume remount, kubectl apply against a mounted file) commonly
+// replace a file via "write a temp file, then rename it over the original", which detaches an
+// inode-level watch on the file and silently stops delivering further events. Watching the
+// directory and filtering by filename survives that replacement.
+//
+// The returned stop function stops the underlying watcher; callers should defer it.
+func NewWatchingEngine(path string, logger *zap.Logger) (engine *URLPolicyEngine, stop func(), err error) {
+	rules, err := LoadRulesFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	engine, err = NewURLPolicyEngine(rules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("localpolicy: resolving %s: %w", path, err)
+	}
+	dir := filepath.Dir(absPath)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, fmt.Errorf("localpolicy: starting file watcher: %w", err)
+	}
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return nil, nil, fmt.Errorf("localpolicy: watching %s: %w", dir, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != absPath {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				rules, err := LoadRulesFile(path)
+				if err != nil {
+					logger.Error("localpolicy: failed to reload rules, keeping previous ruleset", zap.Error(err))
+					continue
+				}
+				if err := engine.Reload(rules); err != nil {
+					logger.Error("localpolicy: failed to compile reloaded rules, keeping previous ruleset", zap.Error(err))
+					continue
+				}
+				logger.Info("localpolicy: reloaded rules", zap.String("path", path), zap.Int("num_rules", len(rules)))
+			case watchErr, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Error("localpolicy: file watcher error", zap.Error(watchErr))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	stop = func() {
+		close(done)
+		_ = watcher.Close()
+	}
+	return engine, stop, nil
+}
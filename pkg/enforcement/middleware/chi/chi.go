@@ -0,0 +1,17 @@
+// Package chi adapts enforcement/middleware's URL enforcer to go-chi/chi's middleware signature,
+// which is identical to net/http's func(http.Handler) http.Handler but kept as its own package so
+// callers can `chi.Use(permitchi.Middleware(e, opts...))` without an explicit net/http import.
+package chi
+
+import (
+	"net/http"
+
+	"github.com/permitio/permit-golang/pkg/enforcement"
+	"github.com/permitio/permit-golang/pkg/enforcement/middleware"
+)
+
+// Middleware builds a chi-compatible middleware (func(http.Handler) http.Handler) that authorizes
+// requests through e. See middleware.NewURLEnforcer for the available opts.
+func Middleware(e *enforcement.PermitEnforcer, opts ...middleware.Option) func(http.Handler) http.Handler {
+	return middleware.NewURLEnforcer(e, opts...)
+}
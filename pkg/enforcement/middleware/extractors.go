@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/permitio/permit-golang/pkg/enforcement"
+)
+
+var (
+	// ErrMissingBearerToken is returned by BearerJWTExtractor when the request has no
+	// (or a malformed) Authorization: Bearer header.
+	ErrMissingBearerToken = errors.New("enforcement/middleware: missing bearer token")
+	// ErrMissingSessionCookie is returned by SessionTokenExtractor when the configured cookie is
+	// absent from the request.
+	ErrMissingSessionCookie = errors.New("enforcement/middleware: missing session cookie")
+)
+
+// ClaimsToUser maps the decoded claims of a bearer JWT to an enforcement.User. Callers supply
+// this because the claim layout (and how it maps onto a Permit user key/attributes) is
+// application-specific.
+type ClaimsToUser func(claims map[string]interface{}) (enforcement.User, error)
+
+// ParseBearerJWT decodes and verifies the token out of the Authorization: Bearer header, e.g.
+// using (*github.com/golang-jwt/jwt/v5.Parser).Parse, and returns its claims.
+type ParseBearerJWT func(tokenString string) (claims map[string]interface{}, err error)
+
+// BearerJWTExtractor builds a UserExtractor that reads the Authorization: Bearer header, decodes
+// it with parse, and maps the resulting claims to an enforcement.User with toUser.
+func BearerJWTExtractor(parse ParseBearerJWT, toUser ClaimsToUser) UserExtractor {
+	return func(r *http.Request) (enforcement.User, error) {
+		tokenString, err := bearerToken(r)
+		if err != nil {
+			return enforcement.User{}, err
+		}
+		claims, err := parse(tokenString)
+		if err != nil {
+			return enforcement.User{}, err
+		}
+		return toUser(claims)
+	}
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if header == "" || !strings.HasPrefix(header, prefix) {
+		return "", ErrMissingBearerToken
+	}
+	return strings.TrimPrefix(header, prefix), nil
+}
+
+// SessionLookup resolves an enforcement.User from an opaque session token, typically via a
+// session store or cache.
+type SessionLookup func(r *http.Request, sessionToken string) (enforcement.User, error)
+
+// SessionTokenExtractor builds a UserExtractor that reads sessionToken from the named cookie and
+// resolves it to an enforcement.User via lookup.
+func SessionTokenExtractor(cookieName string, lookup SessionLookup) UserExtractor {
+	return func(r *http.Request) (enforcement.User, error) {
+		cookie, err := r.Cookie(cookieName)
+		if err != nil {
+			return enforcement.User{}, ErrMissingSessionCookie
+		}
+		return lookup(r, cookie.Value)
+	}
+}
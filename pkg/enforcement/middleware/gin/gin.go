@@ -0,0 +1,30 @@
+// Package gin adapts enforcement/middleware's URL enforcer into a gin.HandlerFunc, so it can be
+// registered with engine.Use(...) or router.Group(...).Use(...) like any other gin middleware.
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/permitio/permit-golang/pkg/enforcement"
+	"github.com/permitio/permit-golang/pkg/enforcement/middleware"
+)
+
+// Middleware builds a gin.HandlerFunc that authorizes requests through e before the rest of the
+// gin chain runs. See middleware.NewURLEnforcer for the available opts.
+func Middleware(e *enforcement.PermitEnforcer, opts ...middleware.Option) gin.HandlerFunc {
+	wrapped := middleware.NewURLEnforcer(e, opts...)
+
+	return func(c *gin.Context) {
+		allowedThrough := false
+		next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			allowedThrough = true
+			c.Next()
+		})
+		wrapped(next).ServeHTTP(c.Writer, c.Request)
+		if !allowedThrough {
+			c.Abort()
+		}
+	}
+}
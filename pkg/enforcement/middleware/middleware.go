@@ -0,0 +1,213 @@
+// Package middleware adapts enforcement.PermitEnforcer.CheckUrl into standard net/http
+// middleware, so that incoming requests can be authorized automatically instead of every
+// handler calling CheckUrl by hand.
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/permitio/permit-golang/pkg/enforcement"
+)
+
+// urlChecker is satisfied by *enforcement.PermitEnforcer. Declaring it as an interface here, like
+// the other enforcement/middleware seams (UserExtractor, TenantResolver, FailureMode), lets tests
+// substitute a fake PDP decision instead of driving a real enforcement.PermitEnforcer.
+type urlChecker interface {
+	CheckUrlWithContext(ctx context.Context, user enforcement.User, url enforcement.URL, method enforcement.Method, tenant enforcement.Tenant, additionalContext ...map[string]string) (bool, error)
+}
+
+// UserExtractor resolves the acting enforcement.User from an incoming request, e.g. by decoding
+// a bearer JWT or looking up a session token. See BearerJWTExtractor and SessionTokenExtractor
+// for ready-made adapters.
+type UserExtractor func(r *http.Request) (enforcement.User, error)
+
+// TenantResolver resolves the enforcement.Tenant an incoming request should be checked against.
+type TenantResolver func(r *http.Request) (enforcement.Tenant, error)
+
+// FailureMode decides how to react when the PDP call itself errors out (as opposed to returning
+// an explicit deny). It returns true to let the request through, false to block it.
+type FailureMode func(r *http.Request, err error) bool
+
+// FailOpen lets requests through when the PDP is unreachable or errors. Use when availability
+// matters more than strict denial-by-default, e.g. non-critical internal tooling.
+func FailOpen(_ *http.Request, _ error) bool {
+	return true
+}
+
+// FailClosed denies requests when the PDP is unreachable or errors. This is the safer default
+// and is used unless a custom FailureMode is supplied via WithFailureMode.
+func FailClosed(_ *http.Request, _ error) bool {
+	return false
+}
+
+// DeniedResponse is the JSON body written when a request is denied, whether by an explicit PDP
+// deny decision or by a FailureMode that chose to block the request.
+type DeniedResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type config struct {
+	extractUser    UserExtractor
+	resolveTenant  TenantResolver
+	contextHeaders []string
+	contextQuery   []string
+	deniedStatus   int
+	failureMode    FailureMode
+	logger         *zap.Logger
+}
+
+// Option configures NewURLEnforcer.
+type Option func(*config)
+
+// WithUserExtractor sets how the acting user is resolved from the request. It is required;
+// NewURLEnforcer panics if it is not supplied.
+func WithUserExtractor(extractUser UserExtractor) Option {
+	return func(c *config) {
+		c.extractUser = extractUser
+	}
+}
+
+// WithTenantResolver sets how the tenant is resolved from the request. Defaults to always
+// resolving enforcement.DefaultTenant.
+func WithTenantResolver(resolveTenant TenantResolver) Option {
+	return func(c *config) {
+		c.resolveTenant = resolveTenant
+	}
+}
+
+// WithContextHeaders includes the named request headers in the additionalContext map passed to
+// CheckUrl, keyed by header name.
+func WithContextHeaders(headers ...string) Option {
+	return func(c *config) {
+		c.contextHeaders = headers
+	}
+}
+
+// WithContextQueryParams includes the named query string parameters in the additionalContext map
+// passed to CheckUrl, keyed by parameter name.
+func WithContextQueryParams(params ...string) Option {
+	return func(c *config) {
+		c.contextQuery = params
+	}
+}
+
+// WithDeniedStatus overrides the HTTP status written whenever a request is blocked: an explicit
+// deny decision, a user or tenant that can't be resolved, or a FailureMode that blocks. Defaults
+// to http.StatusForbidden.
+func WithDeniedStatus(status int) Option {
+	return func(c *config) {
+		c.deniedStatus = status
+	}
+}
+
+// WithFailureMode overrides how the middleware reacts when CheckUrl itself errors (PDP
+// unreachable, timed out, malformed response, ...). Defaults to FailClosed.
+func WithFailureMode(mode FailureMode) Option {
+	return func(c *config) {
+		c.failureMode = mode
+	}
+}
+
+// WithLogger sets where the middleware logs the underlying error behind a denied request (user
+// resolution, tenant resolution, or the PDP check itself). Defaults to a no-op logger. The
+// client-facing response body never includes this detail - see writeDenied.
+func WithLogger(logger *zap.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// NewURLEnforcer builds net/http middleware that authorizes every incoming request against e
+// using enforcement.PermitEnforcer.CheckUrlWithContext under the request's context, before the
+// wrapped handler runs. WithUserExtractor
+// must be supplied via opts; all other behavior has sane defaults (see the With* options).
+func NewURLEnforcer(e urlChecker, opts ...Option) func(http.Handler) http.Handler {
+	cfg := &config{
+		resolveTenant: func(*http.Request) (enforcement.Tenant, error) {
+			return enforcement.DefaultTenant, nil
+		},
+		deniedStatus: http.StatusForbidden,
+		failureMode:  FailClosed,
+		logger:       zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.extractUser == nil {
+		panic("enforcement/middleware: NewURLEnforcer requires WithUserExtractor")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := cfg.extractUser(r)
+			if err != nil {
+				cfg.logger.Error("enforcement/middleware: could not resolve user", zap.Error(err))
+				writeDenied(w, cfg.deniedStatus, "could not resolve user")
+				return
+			}
+
+			tenant, err := cfg.resolveTenant(r)
+			if err != nil {
+				cfg.logger.Error("enforcement/middleware: could not resolve tenant", zap.Error(err))
+				writeDenied(w, cfg.deniedStatus, "could not resolve tenant")
+				return
+			}
+
+			allowed, err := e.CheckUrlWithContext(
+				r.Context(),
+				user,
+				enforcement.URL(r.URL.Path),
+				enforcement.Method(r.Method),
+				tenant,
+				buildContext(r, cfg),
+			)
+			if err != nil {
+				if cfg.failureMode(r, err) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				cfg.logger.Error("enforcement/middleware: authorization check failed", zap.Error(err))
+				writeDenied(w, cfg.deniedStatus, "authorization check failed")
+				return
+			}
+			if !allowed {
+				writeDenied(w, cfg.deniedStatus, "not authorized")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func buildContext(r *http.Request, cfg *config) map[string]string {
+	ctx := make(map[string]string, len(cfg.contextHeaders)+len(cfg.contextQuery))
+	for _, header := range cfg.contextHeaders {
+		if v := r.Header.Get(header); v != "" {
+			ctx[header] = v
+		}
+	}
+	query := r.URL.Query()
+	for _, param := range cfg.contextQuery {
+		if v := query.Get(param); v != "" {
+			ctx[param] = v
+		}
+	}
+	return ctx
+}
+
+// writeDenied writes the client-facing deny response. message must stay generic - it is never the
+// underlying error, which may contain PDP connection details, JWT-parsing internals, or other
+// information the caller shouldn't see. Callers log the real error server-side before calling
+// this.
+func writeDenied(w http.ResponseWriter, status int, message string) {
+	body := DeniedResponse{Error: "permission_denied", Message: message}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
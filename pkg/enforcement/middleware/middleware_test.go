@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/permitio/permit-golang/pkg/enforcement"
+)
+
+// fakeChecker is a urlChecker stand-in so these tests never need a real enforcement.PermitEnforcer
+// or PDP.
+type fakeChecker struct {
+	allowed bool
+	err     error
+}
+
+func (f fakeChecker) CheckUrlWithContext(_ context.Context, _ enforcement.User, _ enforcement.URL, _ enforcement.Method, _ enforcement.Tenant, _ ...map[string]string) (bool, error) {
+	return f.allowed, f.err
+}
+
+func decodeDenied(t *testing.T, rec *httptest.ResponseRecorder) DeniedResponse {
+	t.Helper()
+	var body DeniedResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding denied response body: %v", err)
+	}
+	return body
+}
+
+func TestNewURLEnforcerRequiresUserExtractor(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected NewURLEnforcer to panic without WithUserExtractor")
+		}
+	}()
+	NewURLEnforcer(fakeChecker{allowed: true})
+}
+
+func TestNewURLEnforcerAllowsWhenCheckUrlAllows(t *testing.T) {
+	called := false
+	mw := NewURLEnforcer(fakeChecker{allowed: true}, WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+		return enforcement.User{}, nil
+	}))
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if !called {
+		t.Error("expected the wrapped handler to run")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestNewURLEnforcerDeniesWhenCheckUrlDenies(t *testing.T) {
+	called := false
+	mw := NewURLEnforcer(fakeChecker{allowed: false}, WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+		return enforcement.User{}, nil
+	}))
+	handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if called {
+		t.Error("the wrapped handler should not run on deny")
+	}
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	body := decodeDenied(t, rec)
+	if body.Error != "permission_denied" {
+		t.Errorf("got error %q, want %q", body.Error, "permission_denied")
+	}
+}
+
+func TestNewURLEnforcerDeniedBodyNeverLeaksUnderlyingError(t *testing.T) {
+	sensitive := errors.New("dial tcp 10.0.0.5:443: connection refused: secret-internal-hostname")
+	mw := NewURLEnforcer(fakeChecker{err: sensitive}, WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+		return enforcement.User{}, nil
+	}))
+	handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	body := decodeDenied(t, rec)
+	if body.Message == sensitive.Error() {
+		t.Fatal("denied response body must not include the underlying error text")
+	}
+	if strings.Contains(rec.Body.String(), "10.0.0.5") {
+		t.Errorf("denied response body leaked connection details: %s", rec.Body.String())
+	}
+}
+
+func TestNewURLEnforcerFailureModeDecidesOnCheckUrlError(t *testing.T) {
+	called := false
+	mw := NewURLEnforcer(fakeChecker{err: errors.New("pdp unreachable")},
+		WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+			return enforcement.User{}, nil
+		}),
+		WithFailureMode(FailOpen),
+	)
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if !called {
+		t.Error("FailOpen should let the request through when CheckUrl errors")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestNewURLEnforcerFailClosedDeniesOnCheckUrlError(t *testing.T) {
+	mw := NewURLEnforcer(fakeChecker{err: errors.New("pdp unreachable")},
+		WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+			return enforcement.User{}, nil
+		}),
+	)
+	handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler should not run under the default FailClosed")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestNewURLEnforcerDeniesWhenUserExtractorErrors(t *testing.T) {
+	mw := NewURLEnforcer(fakeChecker{allowed: true}, WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+		return enforcement.User{}, errors.New("no bearer token")
+	}))
+	handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler should not run when the user can't be resolved")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestNewURLEnforcerDeniesWhenTenantResolverErrors(t *testing.T) {
+	mw := NewURLEnforcer(fakeChecker{allowed: true},
+		WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+			return enforcement.User{}, nil
+		}),
+		WithTenantResolver(func(*http.Request) (enforcement.Tenant, error) {
+			return "", errors.New("no tenant header")
+		}),
+	)
+	handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler should not run when the tenant can't be resolved")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestNewURLEnforcerWithDeniedStatusOverride(t *testing.T) {
+	mw := NewURLEnforcer(fakeChecker{allowed: false},
+		WithUserExtractor(func(*http.Request) (enforcement.User, error) {
+			return enforcement.User{}, nil
+		}),
+		WithDeniedStatus(http.StatusNotFound),
+	)
+	handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,215 @@
+// Package openapi derives URL authorization policy from an OpenAPI 3 document, so that the set
+// of (method, path) routes Permit enforces stays in lockstep with the API contract instead of
+// being maintained by hand alongside it.
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/permitio/permit-golang/pkg/enforcement"
+)
+
+// Vendor extension keys read off each operation to resolve its resource/action. When either is
+// absent, the index falls back to convention: the operation's first tag becomes the resource and
+// its operationId becomes the action.
+const (
+	resourceExtension = "x-permit-resource"
+	actionExtension   = "x-permit-action"
+)
+
+// ErrNoMatchingRoute is returned by URLPolicyIndex.Check when the request's method and path don't
+// match any operation in the indexed OpenAPI document.
+var ErrNoMatchingRoute = errors.New("enforcement/openapi: no route in the OpenAPI document matches this request")
+
+// Permission is the resource:action pair a matched route should be checked against.
+type Permission struct {
+	Resource enforcement.Resource
+	Action   enforcement.Action
+}
+
+// Route describes one indexed OpenAPI operation and the permission it resolved to. Exported so
+// tooling (see cmd/permit-openapi-dump) can print the full mapping for review.
+type Route struct {
+	Method       string
+	PathTemplate string
+	Permission   Permission
+}
+
+type compiledRoute struct {
+	Route
+	pathPattern *regexp.Regexp
+}
+
+// URLPolicyIndex maps (method, path-template) pairs parsed out of an OpenAPI 3 document to the
+// resource:action tuple that should be checked for matching requests.
+type URLPolicyIndex struct {
+	routes []compiledRoute
+}
+
+// NewURLPolicyIndexFromFile loads an OpenAPI 3 document from path and builds a URLPolicyIndex
+// from it.
+func NewURLPolicyIndexFromFile(path string) (*URLPolicyIndex, error) {
+	doc, err := openapi3.NewLoader().LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("enforcement/openapi: loading spec: %w", err)
+	}
+	return NewURLPolicyIndex(doc)
+}
+
+// NewURLPolicyIndex builds a URLPolicyIndex from an already-loaded OpenAPI 3 document.
+func NewURLPolicyIndex(doc *openapi3.T) (*URLPolicyIndex, error) {
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("enforcement/openapi: invalid spec: %w", err)
+	}
+
+	idx := &URLPolicyIndex{}
+	for path, item := range doc.Paths {
+		pattern, err := compilePathTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+		for method, op := range item.Operations() {
+			permission, err := permissionFor(op)
+			if err != nil {
+				return nil, fmt.Errorf("enforcement/openapi: %s %s: %w", method, path, err)
+			}
+			idx.routes = append(idx.routes, compiledRoute{
+				Route: Route{
+					Method:       strings.ToUpper(method),
+					PathTemplate: path,
+					Permission:   permission,
+				},
+				pathPattern: pattern,
+			})
+		}
+	}
+
+	// doc.Paths is a plain map, so range order is randomized per iteration - sort routes
+	// deterministically so that overlapping templates for the same concrete path (e.g.
+	// "/users/{id}" and "/users/me") always resolve to the same match regardless of build, with
+	// more specific (fewer path parameters) templates taking precedence.
+	sort.SliceStable(idx.routes, func(i, j int) bool {
+		pi, pj := countParamSegments(idx.routes[i].PathTemplate), countParamSegments(idx.routes[j].PathTemplate)
+		if pi != pj {
+			return pi < pj
+		}
+		if idx.routes[i].PathTemplate != idx.routes[j].PathTemplate {
+			return idx.routes[i].PathTemplate < idx.routes[j].PathTemplate
+		}
+		return idx.routes[i].Method < idx.routes[j].Method
+	})
+
+	return idx, nil
+}
+
+// countParamSegments counts the "{param}" path segments in an OpenAPI path template, used to
+// rank more specific (fully literal) templates ahead of more generic ones.
+func countParamSegments(template string) int {
+	count := 0
+	for _, segment := range strings.Split(template, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			count++
+		}
+	}
+	return count
+}
+
+func permissionFor(op *openapi3.Operation) (Permission, error) {
+	resource := extensionString(op.Extensions, resourceExtension)
+	action := extensionString(op.Extensions, actionExtension)
+
+	if resource == "" && len(op.Tags) > 0 {
+		resource = op.Tags[0]
+	}
+	if action == "" {
+		action = op.OperationID
+	}
+	if resource == "" || action == "" {
+		return Permission{}, fmt.Errorf(
+			"could not derive a resource/action: set %s/%s, or a tag and an operationId",
+			resourceExtension, actionExtension,
+		)
+	}
+
+	return Permission{
+		Resource: enforcement.Resource(resource),
+		Action:   enforcement.Action(action),
+	}, nil
+}
+
+func extensionString(extensions map[string]interface{}, key string) string {
+	raw, ok := extensions[key]
+	if !ok {
+		return ""
+	}
+	if s, ok := raw.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", raw)
+}
+
+// compilePathTemplate turns an OpenAPI path template such as "/orgs/{orgId}/repos/{repoId}" into
+// a regexp that matches it, with each "{param}" segment matching one non-slash path segment.
+func compilePathTemplate(template string) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+
+	for i := 0; i < len(template); {
+		if template[i] != '{' {
+			pattern.WriteString(regexp.QuoteMeta(string(template[i])))
+			i++
+			continue
+		}
+		end := strings.IndexByte(template[i:], '}')
+		if end == -1 {
+			return nil, fmt.Errorf("enforcement/openapi: unterminated path parameter in %q", template)
+		}
+		pattern.WriteString(`[^/]+`)
+		i += end + 1
+	}
+
+	pattern.WriteString("$")
+	return regexp.Compile(pattern.String())
+}
+
+// Routes returns every indexed (method, path template) -> permission mapping, in match order:
+// templates with fewer "{param}" segments first, then by path template, then by method.
+func (idx *URLPolicyIndex) Routes() []Route {
+	routes := make([]Route, len(idx.routes))
+	for i, r := range idx.routes {
+		routes[i] = r.Route
+	}
+	return routes
+}
+
+func (idx *URLPolicyIndex) match(method, path string) (*Route, bool) {
+	method = strings.ToUpper(method)
+	for i := range idx.routes {
+		r := &idx.routes[i]
+		if r.Method == method && r.pathPattern.MatchString(path) {
+			return &r.Route, true
+		}
+	}
+	return nil, false
+}
+
+// Check matches r against the indexed path templates (respecting "{param}" segments) and, on a
+// match, calls the normal Permit Check API with the resolved resource/action instead of CheckUrl.
+// It returns ErrNoMatchingRoute if no operation in the document matches the request's method and
+// path, which usually means the OpenAPI document is missing a route rather than that access
+// should be denied - callers typically want to treat that as a deny-closed configuration error.
+func (idx *URLPolicyIndex) Check(e *enforcement.PermitEnforcer, user enforcement.User, r *http.Request, tenant enforcement.Tenant) (bool, error) {
+	route, ok := idx.match(r.Method, r.URL.Path)
+	if !ok {
+		return false, ErrNoMatchingRoute
+	}
+	return e.Check(user, route.Permission.Action, route.Permission.Resource, tenant)
+}
@@ -0,0 +1,111 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const specWithOverlappingRoutes = `
+openapi: "3.0.0"
+info:
+  title: test
+  version: "1.0"
+paths:
+  /users/{id}:
+    get:
+      operationId: getUserById
+      x-permit-resource: users
+      x-permit-action: read
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+  /users/me:
+    get:
+      operationId: getCurrentUser
+      x-permit-resource: users
+      x-permit-action: read_self
+      responses:
+        "200":
+          description: ok
+`
+
+func mustLoadIndex(t *testing.T, spec string) *URLPolicyIndex {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("loading spec: %v", err)
+	}
+	idx, err := NewURLPolicyIndex(doc)
+	if err != nil {
+		t.Fatalf("building index: %v", err)
+	}
+	return idx
+}
+
+// TestRoutesAreSortedDeterministically guards against doc.Paths (a plain map) producing a
+// different route order on every build: the literal "/users/me" must always be ranked ahead of
+// the more generic "/users/{id}" so it wins the match, regardless of map iteration order.
+func TestRoutesAreSortedDeterministically(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		idx := mustLoadIndex(t, specWithOverlappingRoutes)
+		routes := idx.Routes()
+		if len(routes) != 2 {
+			t.Fatalf("expected 2 routes, got %d", len(routes))
+		}
+		if routes[0].PathTemplate != "/users/me" || routes[1].PathTemplate != "/users/{id}" {
+			t.Fatalf("run %d: expected [/users/me, /users/{id}], got [%s, %s]",
+				i, routes[0].PathTemplate, routes[1].PathTemplate)
+		}
+	}
+}
+
+func TestMatchPrefersMoreSpecificLiteralRoute(t *testing.T) {
+	idx := mustLoadIndex(t, specWithOverlappingRoutes)
+
+	meReq := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+	route, ok := idx.match(meReq.Method, meReq.URL.Path)
+	if !ok {
+		t.Fatal("expected a match for /users/me")
+	}
+	if route.Permission.Action != "read_self" {
+		t.Errorf("GET /users/me resolved to action %q, want %q", route.Permission.Action, "read_self")
+	}
+
+	idReq := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	route, ok = idx.match(idReq.Method, idReq.URL.Path)
+	if !ok {
+		t.Fatal("expected a match for /users/123")
+	}
+	if route.Permission.Action != "read" {
+		t.Errorf("GET /users/123 resolved to action %q, want %q", route.Permission.Action, "read")
+	}
+}
+
+func TestMatchReturnsFalseWhenNoRouteMatches(t *testing.T) {
+	idx := mustLoadIndex(t, specWithOverlappingRoutes)
+	if _, ok := idx.match(http.MethodPost, "/users/me"); ok {
+		t.Error("expected no match for POST /users/me, which isn't in the spec")
+	}
+}
+
+func TestCountParamSegments(t *testing.T) {
+	cases := map[string]int{
+		"/users/me":                    0,
+		"/users/{id}":                  1,
+		"/orgs/{orgId}/repos/{repoId}": 2,
+	}
+	for template, want := range cases {
+		if got := countParamSegments(template); got != want {
+			t.Errorf("countParamSegments(%q) = %d, want %d", template, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package errors
+
+import "fmt"
+
+// PermitDeadlineExceeded is returned by enforcement calls made with a context that was canceled
+// or whose deadline elapsed before a decision was received from the PDP. Callers can type-assert
+// (or errors.As) against this to distinguish "the PDP denied the request" from "the PDP was
+// unreachable or too slow to answer in time", which is typically handled differently (e.g. fail
+// open vs. fail closed) than an explicit deny.
+type PermitDeadlineExceeded struct {
+	Err error
+}
+
+func NewPermitDeadlineExceeded(err error) *PermitDeadlineExceeded {
+	return &PermitDeadlineExceeded{Err: err}
+}
+
+func (e *PermitDeadlineExceeded) Error() string {
+	return fmt.Sprintf("permit: PDP call did not complete before the context deadline: %s", e.Err)
+}
+
+func (e *PermitDeadlineExceeded) Unwrap() error {
+	return e.Err
+}